@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -64,9 +65,29 @@ func (a *App) ReadConfig() {
 	}
 
 	for i, relay := range app.config.Relays {
+		// MQTT remains mandatory even when Transports are configured: it's the relay's primary
+		// connection, not one more Transport, so there's currently no way to run on Transports alone.
 		if relay.MqttHost == "" || relay.MqttPort == 0 {
 			log.Fatalf("Relay %d: MQTT host and port are required configurations.", i)
 		}
+		switch relay.MqttScheme {
+		case "", "tcp", "ssl", "ws", "wss":
+		default:
+			log.Fatalf("Relay %d: mqtt_scheme must be one of tcp, ssl, ws, or wss.", i)
+		}
+		if relay.MqttWill != nil && relay.MqttWill.Topic == "" {
+			log.Fatalf("Relay %d: mqtt_will requires a topic.", i)
+		}
+		if relay.MqttStore != "" && relay.MqttStore != "memory" && relay.MqttStore != "none" && !strings.HasPrefix(relay.MqttStore, "file:") {
+			log.Fatalf("Relay %d: mqtt_store must be memory, none, or file:/path.", i)
+		}
+		for t, transport := range relay.Transports {
+			switch transport.Type {
+			case "nats", "redis", "kafka":
+			default:
+				log.Fatalf("Relay %d: transport %d has an unknown type %q.", i, t, transport.Type)
+			}
+		}
 		if relay.MqttTopic == "" {
 			log.Fatalf("Relay %d: MQTT topic is a required configuration.", i)
 		}
@@ -81,7 +102,41 @@ func (a *App) ReadConfig() {
 				if relay.OscBindPort == relay2.OscBindPort {
 					log.Fatalf("Relay %d: Cannot use the same OSC bind port on 2 different relays.", i)
 				}
+				if relay.HttpBindAddr != "" && relay.HttpBindPort == relay2.HttpBindPort {
+					log.Fatalf("Relay %d: Cannot use the same HTTP bind port on 2 different relays.", i)
+				}
+			}
+		}
+
+		// Compile command address/topic patterns once, up-front, rather than on every message.
+		for c := range relay.Commands {
+			cmd := &relay.Commands[c]
+			// Command containing ${n} placeholders is a template for the MQTT->OSC direction, not an
+			// OSC address pattern, since {} is OSC alternation syntax; only compile it as a Matcher
+			// when it isn't one, so it can drive the OSC->MQTT namespace bridging direction instead.
+			if !mqttPlaceholder.MatchString(cmd.Command) {
+				matcher, err := NewMatcher(cmd.Command)
+				if err != nil {
+					log.Fatalf("Relay %d, command %d: %s", i, c, err)
+				}
+				cmd.matcher = matcher
+			}
+
+			topicMatcher, err := compileMqttTopicFilter(cmd.MqttTopic)
+			if err != nil {
+				log.Fatalf("Relay %d, command %d: %s", i, c, err)
+			}
+			cmd.topicMatcher = topicMatcher
+		}
+
+		// Compile HTTP trigger address patterns.
+		for t := range relay.HttpTriggers {
+			trigger := &relay.HttpTriggers[t]
+			matcher, err := NewMatcher(trigger.Address)
+			if err != nil {
+				log.Fatalf("Relay %d, HTTP trigger %d: %s", i, t, err)
 			}
+			trigger.matcher = matcher
 		}
 	}
 }
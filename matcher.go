@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matcher: A compiled OSC 1.0 address pattern (*, ?, [abc], {foo,bar}, and // wildcards).
+type Matcher struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// NewMatcher: Compiles an OSC address pattern into a Matcher.
+func NewMatcher(pattern string) (*Matcher, error) {
+	re, err := compileOscPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{pattern: pattern, re: re}, nil
+}
+
+// Match: Rather or not address satisfies the compiled pattern.
+func (m *Matcher) Match(address string) bool {
+	if m == nil {
+		return false
+	}
+	return m.re.MatchString(address)
+}
+
+// Submatch: Rather or not address satisfies the compiled pattern, and if so, the segment each
+// wildcard (*, ?, [abc], {foo,bar}) captured, in the order they appear in the pattern.
+func (m *Matcher) Submatch(address string) ([]string, bool) {
+	if m == nil {
+		return nil, false
+	}
+	match := m.re.FindStringSubmatch(address)
+	if match == nil {
+		return nil, false
+	}
+	return match[1:], true
+}
+
+// compileOscPattern: Translates an OSC address pattern into an equivalent anchored regular expression.
+func compileOscPattern(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch c {
+		case '?':
+			b.WriteString("([^/])")
+			i++
+
+		case '*':
+			b.WriteString("([^/]*)")
+			i++
+
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated [ in OSC pattern: %s", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			// "!" only negates a character class when it leads; elsewhere it's a literal character.
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("([")
+			b.WriteString(class)
+			b.WriteString("])")
+			i += end + 1
+
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated { in OSC pattern: %s", pattern)
+			}
+			alts := strings.Split(pattern[i+1:i+end], ",")
+			for a := range alts {
+				alts[a] = regexp.QuoteMeta(alts[a])
+			}
+			b.WriteString("(")
+			b.WriteString(strings.Join(alts, "|"))
+			b.WriteString(")")
+			i += end + 1
+
+		case '/':
+			// "//" is a recursive wildcard matching any number of intermediate address segments.
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				b.WriteString("(?:/.*)?")
+				i += 2
+				// Keep the separator before the next segment, so "/a//b" does not match "/ab".
+				if i < len(pattern) {
+					b.WriteString("/")
+				}
+			} else {
+				b.WriteString("/")
+				i++
+			}
+
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+			i++
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// mqttPlaceholder: Matches ${n} placeholders substituted with a captured MQTT topic filter segment.
+var mqttPlaceholder = regexp.MustCompile(`\$\{(\d+)\}`)
+
+// compileMqttTopicFilter: Compiles an MQTT topic filter containing + or # wildcards into a regular
+// expression capturing each wildcard segment, for substitution via ${1}, ${2}, etc. Returns a nil
+// regexp (and no error) when topic has no wildcards, so callers can fall back to an exact match.
+func compileMqttTopicFilter(topic string) (*regexp.Regexp, error) {
+	if !strings.ContainsAny(topic, "+#") {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	segments := strings.Split(topic, "/")
+	for i, segment := range segments {
+		if i > 0 {
+			b.WriteString("/")
+		}
+		switch segment {
+		case "+":
+			b.WriteString("([^/]+)")
+		case "#":
+			if i != len(segments)-1 {
+				return nil, fmt.Errorf("# must be the last segment of an MQTT topic filter: %s", topic)
+			}
+			b.WriteString("(.*)")
+		default:
+			b.WriteString(regexp.QuoteMeta(segment))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// substitutePlaceholders: Replaces ${1}, ${2}, etc in template with the corresponding capture group.
+func substitutePlaceholders(template string, groups []string) string {
+	return mqttPlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		idx, err := strconv.Atoi(mqttPlaceholder.FindStringSubmatch(match)[1])
+		if err != nil || idx < 1 || idx > len(groups) {
+			return match
+		}
+		return groups[idx-1]
+	})
+}
@@ -2,14 +2,21 @@ package main
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/hypebeast/go-osc/osc"
 )
 
@@ -34,9 +41,17 @@ func (l LogLevel) String() string {
 
 // Relay command definition
 type RelayCommand struct {
-	// Command: The command path to send.
+	// Command: The OSC address to send when triggered from MQTT, or an OSC address pattern
+	// (*, ?, [abc], {foo,bar}, //) that an outgoing OSC message must match to be forwarded to
+	// MqttTopic/MqttSubTopic, in addition to the usual cmd topic. These uses are mutually exclusive:
+	// when Command contains ${1}, ${2}, etc placeholders, it is a template for the former, substituted
+	// with the MQTT topic segments MqttTopic's + or # wildcards captured, and is not compiled as an
+	// OSC address pattern for the latter.
 	Command string `yaml:"command" json:"command"`
-	// MqttTopic: Absolute MQTT topic to subscribe.
+	// MqttTopic: Absolute MQTT topic to subscribe. May use MQTT wildcards (+, #), captured and
+	// substituted into Command's ${n} placeholders. When Command is instead an OSC address pattern,
+	// MqttTopic may use ${1}, ${2}, etc placeholders of its own, substituted with the segments Command's
+	// wildcards captured from the matched OSC address.
 	MqttTopic string `yaml:"mqtt_topic" json:"mqtt_topic"`
 	// MqttSubTopic: Sub topic off relay MQTT topic to subscribe.
 	// osc/example/$SUB_TOPIC
@@ -45,6 +60,61 @@ type RelayCommand struct {
 	DisallowPayload bool `yaml:"disallow_payload" json:"disallow_payload"`
 	// DefaultPayload: Payload to send if no payload is provided via MQTT or if DisallowPayload is true.
 	DefaultPayload []interface{} `yaml:"default_payload" json:"default_payload"`
+	// MqttQos: Overrides the relay's MqttSubscribeQos for this command's topic(s) when subscribing, and
+	// the relay's MqttPublishQos when this command bridges an OSC message back out to MqttTopic/MqttSubTopic.
+	MqttQos *byte `yaml:"mqtt_qos" json:"mqtt_qos"`
+	// MqttRetain: Overrides the relay's Retain() when this command bridges an OSC message back out to
+	// MqttTopic/MqttSubTopic.
+	MqttRetain *bool `yaml:"mqtt_retain" json:"mqtt_retain"`
+
+	// matcher: Command compiled as an OSC address pattern, used to match outgoing OSC messages.
+	matcher *Matcher `yaml:"-" json:"-"`
+	// topicMatcher: MqttTopic compiled with capture groups, non-nil only when it uses + or # wildcards.
+	topicMatcher *regexp.Regexp `yaml:"-" json:"-"`
+}
+
+// matchTopic: Rather or not topic satisfies this command's MqttTopic/MqttSubTopic, and the resulting OSC
+// address to send, with any MqttTopic wildcard captures substituted into Command's ${n} placeholders.
+func (c *RelayCommand) matchTopic(topic string, relayTopic string) (string, bool) {
+	if c.topicMatcher != nil {
+		if groups := c.topicMatcher.FindStringSubmatch(topic); groups != nil {
+			return substitutePlaceholders(c.Command, groups[1:]), true
+		}
+	} else if topic == c.MqttTopic {
+		return c.Command, true
+	}
+	if c.MqttSubTopic != "" && topic == relayTopic+"/"+c.MqttSubTopic {
+		return c.Command, true
+	}
+	return "", false
+}
+
+// publishQos: MqttQos if set, otherwise def.
+func (c *RelayCommand) publishQos(def byte) byte {
+	if c.MqttQos != nil {
+		return *c.MqttQos
+	}
+	return def
+}
+
+// publishRetain: MqttRetain if set, otherwise def.
+func (c *RelayCommand) publishRetain(def bool) bool {
+	if c.MqttRetain != nil {
+		return *c.MqttRetain
+	}
+	return def
+}
+
+// RelayWill: Last-Will-and-Testament configuration published by the broker when this relay drops off unexpectedly.
+type RelayWill struct {
+	// Topic: Topic the will (and the paired online status) is published to.
+	Topic string `yaml:"topic" json:"topic"`
+	// Payload: Payload published by the broker when this relay disconnects uncleanly.
+	Payload string `yaml:"payload" json:"payload"`
+	// Qos: QoS used for the will and the paired online status.
+	Qos byte `yaml:"qos" json:"qos"`
+	// Retain: Rather or not the will and the paired online status are retained.
+	Retain bool `yaml:"retain" json:"retain"`
 }
 
 // Relay OSC subscription
@@ -57,8 +127,90 @@ type RelayOscSubscription struct {
 	Interval time.Duration `yaml:"interval" json:"interval"`
 }
 
+// HttpTrigger: Fires an HTTP request whenever a matching OSC message is dispatched.
+type HttpTrigger struct {
+	// Address: OSC address pattern (*, ?, [abc], {foo,bar}, //) this trigger fires on.
+	Address string `yaml:"address" json:"address"`
+	// Method: HTTP method used for the request.
+	Method string `yaml:"method" json:"method"`
+	// Url: URL the request is sent to.
+	Url string `yaml:"url" json:"url"`
+	// Body: JSON body template. $address and $args are substituted with the OSC address and JSON encoded arguments.
+	Body string `yaml:"body" json:"body"`
+	// Headers: Extra headers sent with the request.
+	Headers map[string]string `yaml:"headers" json:"headers"`
+
+	// matcher: Address compiled as an OSC address pattern.
+	matcher *Matcher `yaml:"-" json:"-"`
+}
+
+// RelayTls: TLS configuration for a relay's MQTT connection.
+type RelayTls struct {
+	// CaFile: Path to a PEM encoded CA bundle used to verify the broker's certificate.
+	CaFile string `yaml:"ca_file" json:"ca_file"`
+	// CertFile: Path to a PEM encoded client certificate for mutual TLS.
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	// KeyFile: Path to the PEM encoded private key matching CertFile.
+	KeyFile string `yaml:"key_file" json:"key_file"`
+	// InsecureSkipVerify: Disables verification of the broker's certificate chain and host name.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify" json:"insecure_skip_verify"`
+	// AlpnProtocols: ALPN protocols to offer during the TLS handshake.
+	AlpnProtocols []string `yaml:"alpn_protocols" json:"alpn_protocols"`
+	// MinVersion: Minimum TLS version to accept, e.g. "1.0", "1.1", "1.2", "1.3". Defaults to "1.2".
+	MinVersion string `yaml:"min_version" json:"min_version"`
+}
+
+// tlsVersions: Maps a MinVersion config string to its crypto/tls constant.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Config: Builds a *tls.Config from the RelayTls settings.
+func (t *RelayTls) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify,
+		NextProtos:         t.AlpnProtocols,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if t.MinVersion != "" {
+		version, ok := tlsVersions[t.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown min_version: %s", t.MinVersion)
+		}
+		config.MinVersion = version
+	}
+
+	if t.CaFile != "" {
+		ca, err := os.ReadFile(t.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("unable to parse CA bundle: %s", t.CaFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if t.CertFile != "" && t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
 // Relay configurations
 type Relay struct {
+	// MqttScheme: Scheme used to connect to the MQTT broker: tcp, ssl, ws, or wss. Defaults to tcp.
+	MqttScheme string `yaml:"mqtt_scheme" json:"mqtt_scheme"`
 	// MqttHost: Hostname of the MQTT broker.
 	MqttHost string `yaml:"mqtt_host" json:"mqtt_host"`
 	// MqttPort: Port of the MQTT broker.
@@ -69,6 +221,28 @@ type Relay struct {
 	MqttUser string `yaml:"mqtt_user" json:"mqtt_user"`
 	// MqttPassword: Password used for MQTT authentication.
 	MqttPassword string `yaml:"mqtt_password" json:"mqtt_password"`
+	// MqttTls: TLS configuration used when MqttScheme is ssl or wss.
+	MqttTls *RelayTls `yaml:"mqtt_tls" json:"mqtt_tls"`
+	// MqttPublishQos: Default QoS used when this relay publishes to MQTT.
+	MqttPublishQos byte `yaml:"mqtt_publish_qos" json:"mqtt_publish_qos"`
+	// MqttSubscribeQos: Default QoS used when this relay subscribes to MQTT.
+	MqttSubscribeQos byte `yaml:"mqtt_subscribe_qos" json:"mqtt_subscribe_qos"`
+	// MqttRetain: Rather or not messages published by this relay are retained. Defaults to true.
+	MqttRetain *bool `yaml:"mqtt_retain" json:"mqtt_retain"`
+	// MqttWill: Last-Will-and-Testament registered with the broker for this relay.
+	MqttWill *RelayWill `yaml:"mqtt_will" json:"mqtt_will"`
+	// MqttCleanSession: Rather or not to start a clean MQTT session on connect. Defaults to true.
+	MqttCleanSession *bool `yaml:"mqtt_clean_session" json:"mqtt_clean_session"`
+	// MqttConnectRetryInterval: How long to wait between connection attempts while the initial connect is retried.
+	MqttConnectRetryInterval time.Duration `yaml:"mqtt_connect_retry_interval" json:"mqtt_connect_retry_interval"`
+	// MqttMaxReconnectInterval: The maximum time to wait between reconnect attempts once connected.
+	MqttMaxReconnectInterval time.Duration `yaml:"mqtt_max_reconnect_interval" json:"mqtt_max_reconnect_interval"`
+	// MqttStore: Persistence used for QoS>0 inflight messages: "memory" (default), "file:/path", or "none".
+	MqttStore string `yaml:"mqtt_store" json:"mqtt_store"`
+	// Transports: Additional message bus backends this relay fans OSC events out to and accepts commands
+	// from, alongside its still-mandatory MQTT connection (MqttHost/MqttPort above, MqttTopic below) — there is
+	// currently no way to run a relay on NATS/Redis/Kafka alone, without MQTT.
+	Transports []TransportConfig `yaml:"transports" json:"transports"`
 	// MqttTopic: Topic where MQTT messages are pushed and received.
 	// Set topic to `osc/example` and the following topics will be setup.
 	// osc/example/cmd/$OSC_CMD - Any commands received on OSC will publish here.
@@ -99,6 +273,14 @@ type Relay struct {
 	// RelayOscSubscriptions: OSC Comamnds to send at regular intervals. Useful for OSC servers that offers data subscriptions.
 	OscSubscriptions []RelayOscSubscription `yaml:"osc_subscriptions" json:"osc_subscriptions"`
 
+	// HttpTriggers: HTTP requests fired when a matching OSC message is dispatched.
+	HttpTriggers []HttpTrigger `yaml:"http_triggers" json:"http_triggers"`
+	// HttpBindAddr: Bind address of the embedded HTTP server.
+	// When set with HttpBindPort, exposes POST /osc/*address to send OSC messages, mirroring MqttTopic/send.
+	HttpBindAddr string `yaml:"http_bind_addr" json:"http_bind_addr"`
+	// HttpBindPort: Port of the embedded HTTP server.
+	HttpBindPort int `yaml:"http_bind_port" json:"http_bind_port"`
+
 	// LogLevel: How much logging.
 	// 0 - Errors
 	// 1 - MQTT and OSC receive logging.
@@ -115,8 +297,23 @@ type Relay struct {
 	// OscServerConn: Server connection.
 	// The OSC software is limited in bidirectional support, so I do my own connection here.
 	OscServerConn net.PacketConn `yaml:"-" json:"-"`
+	// HttpServer: Embedded HTTP server.
+	HttpServer *http.Server `yaml:"-" json:"-"`
+	// transports: Connected instances of Transports.
+	transports []Transport `yaml:"-" json:"-"`
 }
 
+// noopStore: A mqtt.Store that persists nothing, used when MqttStore is set to "none".
+type noopStore struct{}
+
+func (*noopStore) Open()                                         {}
+func (*noopStore) Put(key string, message packets.ControlPacket) {}
+func (*noopStore) Get(key string) packets.ControlPacket          { return nil }
+func (*noopStore) All() []string                                 { return nil }
+func (*noopStore) Del(key string)                                {}
+func (*noopStore) Close()                                        {}
+func (*noopStore) Reset()                                        {}
+
 // OscMessage: Used for json encode/decode to/from MQTT for bundles.
 type OscMessage struct {
 	Address   string        `json:"address"`
@@ -135,6 +332,36 @@ type OscDispatcher struct {
 	r *Relay
 }
 
+// Retain: Rather or not this relay's published messages should be retained. Defaults to true.
+func (r *Relay) Retain() bool {
+	if r.MqttRetain == nil {
+		return true
+	}
+	return *r.MqttRetain
+}
+
+// CleanSession: Rather or not the MQTT session should be cleaned on connect. Defaults to true.
+func (r *Relay) CleanSession() bool {
+	if r.MqttCleanSession == nil {
+		return true
+	}
+	return *r.MqttCleanSession
+}
+
+// Store: Builds the persistence Store configured via MqttStore ("memory", "file:/path", or "none").
+func (r *Relay) Store() mqtt.Store {
+	switch {
+	case r.MqttStore == "" || r.MqttStore == "memory":
+		return mqtt.NewMemoryStore()
+	case r.MqttStore == "none":
+		return new(noopStore)
+	case strings.HasPrefix(r.MqttStore, "file:"):
+		return mqtt.NewFileStore(strings.TrimPrefix(r.MqttStore, "file:"))
+	default:
+		return mqtt.NewMemoryStore()
+	}
+}
+
 // makeBundle: Makes an OscBundle from an osc.Bundle.
 func (d OscDispatcher) makeBundle(bundle *osc.Bundle) *OscBundle {
 	b := new(OscBundle)
@@ -171,9 +398,35 @@ func (d OscDispatcher) Dispatch(packet osc.Packet) {
 				d.r.Log(ErrorLog, "Json Encode: %s", err)
 				return
 			}
-			d.r.MqttClient.Publish(topic, 0, true, data)
+			d.r.publishAll(topic, data)
 			d.r.Log(SendLog, "-> [MQTT] %s: %s", topic, data)
 
+			// Fire any HTTP triggers whose address pattern matches this message.
+			for _, trigger := range d.r.HttpTriggers {
+				if trigger.matcher.Match(message.Address) {
+					go d.r.FireHttpTrigger(trigger, message.Address, message.Arguments)
+				}
+			}
+
+			// Check commands to see if one matches this message's address, letting a single command
+			// entry bridge a whole namespace onto its own MQTT topic(s) in addition to the cmd topic
+			// above, substituting the segments the address pattern captured into MqttTopic/MqttSubTopic's
+			// own ${n} placeholders.
+			for _, cmd := range d.r.Commands {
+				groups, matched := cmd.matcher.Submatch(message.Address)
+				if !matched {
+					continue
+				}
+				qos := cmd.publishQos(d.r.MqttPublishQos)
+				retain := cmd.publishRetain(d.r.Retain())
+				if cmd.MqttTopic != "" {
+					d.r.publish(substitutePlaceholders(cmd.MqttTopic, groups), data, qos, retain)
+				}
+				if cmd.MqttSubTopic != "" {
+					d.r.publish(d.r.MqttTopic+"/"+substitutePlaceholders(cmd.MqttSubTopic, groups), data, qos, retain)
+				}
+			}
+
 		// Bundle packets are capable of having multiple messages and bundles embeded in it,
 		//  so I translate to my own bundle structure that is JSON aware.
 		case *osc.Bundle:
@@ -185,7 +438,7 @@ func (d OscDispatcher) Dispatch(packet osc.Packet) {
 				d.r.Log(ErrorLog, "Json Encode: %s", err)
 				return
 			}
-			d.r.MqttClient.Publish(topic, 0, true, data)
+			d.r.publishAll(topic, data)
 			d.r.Log(SendLog, "-> [MQTT] %s: %s", topic, data)
 		}
 	}
@@ -257,7 +510,92 @@ func (r *Relay) SendStatus() {
 	}
 
 	// Send config.
-	r.MqttClient.Publish(r.MqttTopic+"/status", 0, true, config)
+	r.publishAll(r.MqttTopic+"/status", config)
+}
+
+// publishAll: Publishes to the primary MQTT connection and any additional transports configured, at
+// this relay's default QoS and retain settings.
+func (r *Relay) publishAll(topic string, payload []byte) {
+	r.publish(topic, payload, r.MqttPublishQos, r.Retain())
+}
+
+// publish: Publishes to the primary MQTT connection and any additional transports configured, at the
+// given QoS and retain settings.
+func (r *Relay) publish(topic string, payload []byte, qos byte, retain bool) {
+	r.MqttClient.Publish(topic, qos, retain, payload)
+	for _, t := range r.transports {
+		if err := t.Publish(topic, payload, PubOpts{Qos: qos, Retain: retain}); err != nil {
+			r.Log(ErrorLog, "Transport Publish Error: %s", err)
+		}
+	}
+}
+
+// FireHttpTrigger: Sends the HTTP request configured by trigger, substituting $address and $args into its body.
+func (r *Relay) FireHttpTrigger(trigger HttpTrigger, address string, arguments []interface{}) {
+	argsData, err := json.Marshal(arguments)
+	if err != nil {
+		r.Log(ErrorLog, "Json Encode: %s", err)
+		return
+	}
+
+	body := strings.ReplaceAll(trigger.Body, "$address", address)
+	body = strings.ReplaceAll(body, "$args", string(argsData))
+
+	req, err := http.NewRequest(trigger.Method, trigger.Url, strings.NewReader(body))
+	if err != nil {
+		r.Log(ErrorLog, "Http Trigger Error: %s", err)
+		return
+	}
+	for key, value := range trigger.Headers {
+		req.Header.Set(key, value)
+	}
+
+	r.Log(SendLog, "-> [HTTP] %s %s: %s", trigger.Method, trigger.Url, body)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.Log(ErrorLog, "Http Trigger Error: %s", err)
+		return
+	}
+	defer resp.Body.Close()
+}
+
+// HttpOnRequest: Handles POST /osc/*address, translating the JSON argument array body into an OscSend.
+func (r *Relay) HttpOnRequest(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Verify arbritary commands can be sent.
+	if r.OscDisallowArbritaryCommand {
+		http.Error(w, "arbitrary commands are disabled on this relay", http.StatusForbidden)
+		return
+	}
+
+	address := strings.TrimPrefix(req.URL.Path, "/osc")
+	if address == "" {
+		address = "/"
+	}
+
+	// Parse the arguments.
+	var arguments []interface{}
+	defer req.Body.Close()
+	if err := json.NewDecoder(req.Body).Decode(&arguments); err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Log(ReceiveLog, "<- [HTTP] %s: %s", address, arguments)
+
+	// Create and send OSC message.
+	oscMessage := osc.NewMessage(address)
+	oscMessage.Arguments = arguments
+	if err := r.OscSend(oscMessage); err != nil {
+		r.Log(ErrorLog, "Send Error: %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // MakeOSCBundle: Makes an osc.Bundle. from an OscBundle.
@@ -279,21 +617,26 @@ func (r *Relay) MakeOSCBundle(bundle *OscBundle) *osc.Bundle {
 	return b
 }
 
-// MqttOnEvent: Handle MQTT events.
+// MqttOnEvent: Handle events received over the primary MQTT connection.
 func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
-	r.Log(ReceiveLog, "<- [MQTT] %s: %s\n", message.Topic(), message.Payload())
+	r.OnMessage(message.Topic(), message.Payload())
+}
+
+// OnMessage: Handle a message received on a subscribed topic, regardless of which transport delivered it.
+func (r *Relay) OnMessage(topic string, payload []byte) {
+	r.Log(ReceiveLog, "<- [MQTT] %s: %s\n", topic, payload)
 
 	// Check commands to see if one matches this topic.
 	for _, cmd := range r.Commands {
-		if message.Topic() == cmd.MqttTopic ||
-			(cmd.MqttSubTopic != "" && message.Topic() == r.MqttTopic+"/"+cmd.MqttSubTopic) {
+		oscAddress, matched := cmd.matchTopic(topic, r.MqttTopic)
+		if matched {
 			// Configure OSC message.
-			oscMessage := osc.NewMessage(cmd.Command)
+			oscMessage := osc.NewMessage(oscAddress)
 
 			// If arguments allowed and provided, parse, otherwise use default payload.
 			var arguments []interface{}
-			if !cmd.DisallowPayload && len(message.Payload()) != 0 {
-				err := json.Unmarshal(message.Payload(), &arguments)
+			if !cmd.DisallowPayload && len(payload) != 0 {
+				err := json.Unmarshal(payload, &arguments)
 				if err != nil {
 					r.Log(ErrorLog, "Json Error: %s", err)
 					return
@@ -312,7 +655,7 @@ func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
 	}
 
 	// If standard send topic.
-	if strings.HasPrefix(message.Topic(), r.MqttTopic+"/send") {
+	if strings.HasPrefix(topic, r.MqttTopic+"/send") {
 		// Verify arbritary commands can be sent.
 		if r.OscDisallowArbritaryCommand {
 			r.Log(ErrorLog, "Arbritary commands are disabled on this relay.")
@@ -320,15 +663,15 @@ func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
 		}
 
 		// Get the command from topic.
-		cmd := strings.Replace(message.Topic(), r.MqttTopic+"/send", "", 1)
+		cmd := strings.Replace(topic, r.MqttTopic+"/send", "", 1)
 		if cmd == "" {
 			cmd = "/"
 		}
 
 		// Parse the arguments.
 		var arguments []interface{}
-		if len(message.Payload()) != 0 {
-			err := json.Unmarshal(message.Payload(), &arguments)
+		if len(payload) != 0 {
+			err := json.Unmarshal(payload, &arguments)
 			if err != nil {
 				r.Log(ErrorLog, "Json Error: %s", err)
 				return
@@ -344,7 +687,7 @@ func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
 		if err != nil {
 			r.Log(ErrorLog, "Send Error: %s", err)
 		}
-	} else if message.Topic() == r.MqttTopic+"/bundle/send" {
+	} else if topic == r.MqttTopic+"/bundle/send" {
 		// Verify arbritary commands can be sent.
 		if r.OscDisallowArbritaryCommand {
 			r.Log(ErrorLog, "Arbritary commands are disabled on this relay.")
@@ -353,7 +696,7 @@ func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
 
 		// Create bundle.
 		bundle := new(OscBundle)
-		err := json.Unmarshal(message.Payload(), bundle)
+		err := json.Unmarshal(payload, bundle)
 		if err != nil {
 			r.Log(ErrorLog, "Json Error: %s", err)
 			return
@@ -367,19 +710,94 @@ func (r *Relay) MqttOnEvent(client mqtt.Client, message mqtt.Message) {
 		if err != nil {
 			r.Log(ErrorLog, "Send Error: %s", err)
 		}
-	} else if message.Topic() == r.MqttTopic+"/status/check" {
+	} else if topic == r.MqttTopic+"/status/check" {
 		r.SendStatus()
 	}
 }
 
-// MqttSubscribe: Subscribe to MQTT Topic.
-func (r *Relay) MqttSubscribe(topic string) {
+// MqttSubscribe: Subscribe to MQTT Topic at the given QoS.
+func (r *Relay) MqttSubscribe(topic string, qos byte) {
 	r.Log(DebugLog, "Subscribing MQTT: %s", topic)
-	if t := r.MqttClient.Subscribe(topic, 0, r.MqttOnEvent); t.Wait() && t.Error() != nil {
+	if t := r.MqttClient.Subscribe(topic, qos, r.MqttOnEvent); t.Wait() && t.Error() != nil {
 		r.Log(ErrorLog, "MQTT Subscribe Error: %s", t.Error())
 	}
 }
 
+// transportSubscribe: Subscribe a Transport to topic, routing received messages through OnMessage.
+func (r *Relay) transportSubscribe(t Transport, topic string) {
+	r.Log(DebugLog, "Subscribing transport: %s", topic)
+	if err := t.Subscribe(topic, r.OnMessage); err != nil {
+		r.Log(ErrorLog, "Transport Subscribe Error: %s", err)
+	}
+}
+
+// startTransports: Connects every additional transport configured and subscribes it to the same topic
+// strings used on the primary MQTT connection. The strings are passed to each backend unchanged, and
+// NATS, Redis and Kafka do not treat MQTT's + and # as wildcards, so over these transports only exact
+// topics (bundle/send, status/check, and command topics without wildcards) deliver commands; arbitrary
+// send/ addresses are not accepted over them.
+func (r *Relay) startTransports() {
+	for _, cfg := range r.Transports {
+		transport, err := newTransport(cfg)
+		if err != nil {
+			log.Fatalf("Transport error: %s", err)
+		}
+		if err := transport.Connect(); err != nil {
+			log.Fatalf("Transport %s connect error: %s", cfg.Type, err)
+		}
+
+		r.transportSubscribe(transport, r.MqttTopic+"/send/#")
+		r.transportSubscribe(transport, r.MqttTopic+"/bundle/send")
+		r.transportSubscribe(transport, r.MqttTopic+"/status/check")
+		for _, cmd := range r.Commands {
+			if cmd.MqttTopic != "" {
+				r.transportSubscribe(transport, cmd.MqttTopic)
+			}
+			if cmd.MqttSubTopic != "" {
+				r.transportSubscribe(transport, r.MqttTopic+"/"+cmd.MqttSubTopic)
+			}
+		}
+
+		r.transports = append(r.transports, transport)
+	}
+}
+
+// MqttOnConnect: Re-subscribes and re-publishes the retained status whenever a connection (or reconnection) is established.
+func (r *Relay) MqttOnConnect(client mqtt.Client) {
+	r.Log(DebugLog, "Connected to MQTT")
+
+	// Publish an online status that pairs with the will's offline payload.
+	if r.MqttWill != nil {
+		r.MqttClient.Publish(r.MqttWill.Topic, r.MqttWill.Qos, r.MqttWill.Retain, "online")
+	}
+
+	// Subscribe to MQTT topics.
+	r.MqttSubscribe(r.MqttTopic+"/send/#", r.MqttSubscribeQos)
+	r.MqttSubscribe(r.MqttTopic+"/bundle/send", r.MqttSubscribeQos)
+	r.MqttSubscribe(r.MqttTopic+"/status/check", r.MqttSubscribeQos)
+	// Subscribe to command topics configured.
+	for _, cmd := range r.Commands {
+		qos := r.MqttSubscribeQos
+		if cmd.MqttQos != nil {
+			qos = *cmd.MqttQos
+		}
+		if cmd.MqttTopic != "" {
+			r.MqttSubscribe(cmd.MqttTopic, qos)
+		}
+		if cmd.MqttSubTopic != "" {
+			r.MqttSubscribe(r.MqttTopic+"/"+cmd.MqttSubTopic, qos)
+		}
+	}
+
+	// Send current config to MQTT.
+	r.SendStatus()
+}
+
+// MqttOnConnectionLost: Logs the broker disconnect. SetAutoReconnect handles getting us back online.
+func (r *Relay) MqttOnConnectionLost(client mqtt.Client, err error) {
+	r.Log(ErrorLog, "MQTT Connection Lost: %s", err)
+}
+
 // Log: Logging function to allow log levels.
 func (r *Relay) Log(level LogLevel, format string, args ...interface{}) {
 	if level <= r.LogLevel {
@@ -387,14 +805,55 @@ func (r *Relay) Log(level LogLevel, format string, args ...interface{}) {
 	}
 }
 
-// Start: Start the relay.
-func (r *Relay) Start() {
-	// Connect to MQTT.
+// connectMqtt: Builds the MQTT client and connects, retrying the initial connect indefinitely per
+// SetConnectRetry and reconnecting after a lost connection per SetAutoReconnect. Run in its own
+// goroutine by Start, since Connect().Wait() does not return while
+// ConnectRetry is still retrying against an unreachable broker, and would otherwise block Start forever.
+func (r *Relay) connectMqtt() {
+	scheme := r.MqttScheme
+	if scheme == "" {
+		scheme = "tcp"
+	}
 	mqtt_opts := mqtt.NewClientOptions()
-	mqtt_opts.AddBroker(fmt.Sprintf("tcp://%s:%d", r.MqttHost, r.MqttPort))
+
+	// ssl/wss schemes require a TLS configuration to be built and applied before connecting.
+	if scheme == "ssl" || scheme == "wss" {
+		relayTls := r.MqttTls
+		if relayTls == nil {
+			relayTls = new(RelayTls)
+		}
+		tlsConfig, err := relayTls.Config()
+		if err != nil {
+			log.Fatalf("MQTT TLS error: %s", err)
+			return
+		}
+		mqtt_opts.SetTLSConfig(tlsConfig)
+	}
+
+	mqtt_opts.AddBroker(fmt.Sprintf("%s://%s:%d", scheme, r.MqttHost, r.MqttPort))
 	mqtt_opts.SetClientID(r.MqttClientId)
 	mqtt_opts.SetUsername(r.MqttUser)
 	mqtt_opts.SetPassword(r.MqttPassword)
+	mqtt_opts.SetCleanSession(r.CleanSession())
+	mqtt_opts.SetStore(r.Store())
+
+	// Register the Last-Will-and-Testament so consumers can detect this relay dropping off the broker.
+	if r.MqttWill != nil {
+		mqtt_opts.SetWill(r.MqttWill.Topic, r.MqttWill.Payload, r.MqttWill.Qos, r.MqttWill.Retain)
+	}
+
+	// Keep the bridge reconnecting on its own rather than relying on an outer retry loop.
+	mqtt_opts.SetAutoReconnect(true)
+	mqtt_opts.SetConnectRetry(true)
+	if r.MqttConnectRetryInterval > 0 {
+		mqtt_opts.SetConnectRetryInterval(r.MqttConnectRetryInterval)
+	}
+	if r.MqttMaxReconnectInterval > 0 {
+		mqtt_opts.SetMaxReconnectInterval(r.MqttMaxReconnectInterval)
+	}
+	mqtt_opts.SetOnConnectHandler(r.MqttOnConnect)
+	mqtt_opts.SetConnectionLostHandler(r.MqttOnConnectionLost)
+
 	r.MqttClient = mqtt.NewClient(mqtt_opts)
 
 	// Connect and failures are fatal exiting service.
@@ -404,19 +863,16 @@ func (r *Relay) Start() {
 		return
 	}
 
-	// Subscribe to MQTT topics.
-	r.MqttSubscribe(r.MqttTopic + "/send/#")
-	r.MqttSubscribe(r.MqttTopic + "/bundle/send")
-	r.MqttSubscribe(r.MqttTopic + "/status/check")
-	// Subscribe to command topics configured.
-	for _, cmd := range r.Commands {
-		if cmd.MqttTopic != "" {
-			r.MqttSubscribe(cmd.MqttTopic)
-		}
-		if cmd.MqttSubTopic != "" {
-			r.MqttSubscribe(r.MqttTopic + "/" + cmd.MqttSubTopic)
-		}
-	}
+	// Connect any additional transports configured.
+	r.startTransports()
+}
+
+// Start: Start the relay.
+func (r *Relay) Start() {
+	// Connect to MQTT in the background: a relay whose broker is unreachable at startup would
+	// otherwise block here indefinitely (see connectMqtt), preventing this relay's own OSC/HTTP
+	// servers, and every later relay in the config, from ever starting.
+	go r.connectMqtt()
 
 	// If an OSC client configuration is provided, setup client.
 	if r.OscHost != "" && r.OscPort != 0 {
@@ -467,6 +923,18 @@ func (r *Relay) Start() {
 		}(subcription)
 	}
 
-	// Send current config to MQTT.
-	r.SendStatus()
+	// If HTTP bind configuration provided, setup an embedded HTTP listener mirroring the MQTT send/# behavior.
+	if r.HttpBindAddr != "" && r.HttpBindPort != 0 {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/osc/", r.HttpOnRequest)
+		r.HttpServer = &http.Server{Addr: fmt.Sprintf("%s:%d", r.HttpBindAddr, r.HttpBindPort), Handler: mux}
+
+		// Run server in thread.
+		go func() {
+			r.Log(DebugLog, "Starting HTTP Server")
+			if err := r.HttpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal(err)
+			}
+		}()
+	}
 }
@@ -0,0 +1,64 @@
+package main
+
+import "fmt"
+
+// PubOpts: Options controlling how a message is published to a Transport.
+type PubOpts struct {
+	// Qos: Delivery guarantee requested for the publish, where the backend supports it.
+	Qos byte
+	// Retain: Rather or not the backend should retain the message for new subscribers.
+	Retain bool
+}
+
+// MsgHandler: Callback invoked when a Transport receives a message on a subscribed topic.
+type MsgHandler func(topic string, payload []byte)
+
+// Transport: A message bus backend a relay can fan OSC events out to and accept commands from, in
+// addition to its MQTT connection. The primary relay connection always speaks MQTT directly (see
+// Relay.Start) rather than going through this interface, and MQTT configuration cannot be omitted;
+// Transport only covers the additional backends configured via Relay.Transports.
+type Transport interface {
+	// Connect: Establish the connection to the backend.
+	Connect() error
+	// Disconnect: Tear down the connection.
+	Disconnect()
+	// Publish: Publish payload to topic.
+	Publish(topic string, payload []byte, opts PubOpts) error
+	// Subscribe: Register handler to be called for every message received on topic.
+	Subscribe(topic string, handler MsgHandler) error
+}
+
+// TransportConfig: One additional pub/sub backend a relay fans OSC events out to.
+type TransportConfig struct {
+	// Type: Which backend this entry configures: nats, redis, or kafka.
+	Type string `yaml:"type" json:"type"`
+	// Nats: Configuration used when Type is "nats".
+	Nats *NatsTransportConfig `yaml:"nats" json:"nats"`
+	// Redis: Configuration used when Type is "redis".
+	Redis *RedisTransportConfig `yaml:"redis" json:"redis"`
+	// Kafka: Configuration used when Type is "kafka".
+	Kafka *KafkaTransportConfig `yaml:"kafka" json:"kafka"`
+}
+
+// newTransport: Builds the Transport configured by cfg.
+func newTransport(cfg TransportConfig) (Transport, error) {
+	switch cfg.Type {
+	case "nats":
+		if cfg.Nats == nil {
+			return nil, fmt.Errorf("nats transport requires a nats configuration block")
+		}
+		return NewNatsTransport(cfg.Nats), nil
+	case "redis":
+		if cfg.Redis == nil {
+			return nil, fmt.Errorf("redis transport requires a redis configuration block")
+		}
+		return NewRedisTransport(cfg.Redis), nil
+	case "kafka":
+		if cfg.Kafka == nil {
+			return nil, fmt.Errorf("kafka transport requires a kafka configuration block")
+		}
+		return NewKafkaTransport(cfg.Kafka), nil
+	default:
+		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+	}
+}
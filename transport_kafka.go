@@ -0,0 +1,103 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// KafkaTransportConfig: Connection settings for a Kafka transport.
+type KafkaTransportConfig struct {
+	// Brokers: Kafka broker addresses, e.g. ["localhost:9092"].
+	Brokers []string `yaml:"brokers" json:"brokers"`
+}
+
+// KafkaTransport: Transport backed by Kafka. Each MQTT-style topic maps to a Kafka topic read/written on
+// partition 0; consumers start from the newest offset since the bridge only cares about live events.
+type KafkaTransport struct {
+	cfg       *KafkaTransportConfig
+	producer  sarama.SyncProducer
+	consumer  sarama.Consumer
+	consumers []sarama.PartitionConsumer
+}
+
+// NewKafkaTransport: Creates a KafkaTransport from its configuration.
+func NewKafkaTransport(cfg *KafkaTransportConfig) *KafkaTransport {
+	return &KafkaTransport{cfg: cfg}
+}
+
+// Connect: Establish the Kafka producer and consumer.
+func (t *KafkaTransport) Connect() error {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	// Every topic is produced and consumed on partition 0 only (see Subscribe), so force manual
+	// partitioning rather than leaving sarama's default hash partitioner free to scatter messages
+	// across partitions the bridge never reads.
+	config.Producer.Partitioner = sarama.NewManualPartitioner
+
+	producer, err := sarama.NewSyncProducer(t.cfg.Brokers, config)
+	if err != nil {
+		return err
+	}
+	t.producer = producer
+
+	consumer, err := sarama.NewConsumer(t.cfg.Brokers, config)
+	if err != nil {
+		return err
+	}
+	t.consumer = consumer
+	return nil
+}
+
+// Disconnect: Close every partition consumer, the consumer, and the producer.
+func (t *KafkaTransport) Disconnect() {
+	for _, pc := range t.consumers {
+		pc.Close()
+	}
+	if t.consumer != nil {
+		t.consumer.Close()
+	}
+	if t.producer != nil {
+		t.producer.Close()
+	}
+}
+
+// kafkaTopicDisallowed: Characters not allowed unescaped in a Kafka topic name ([a-zA-Z0-9._-]).
+var kafkaTopicDisallowed = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// kafkaTopic: Sanitizes a relay topic (slash-delimited, e.g. osc/example/cmd/foo) into a valid Kafka
+// topic name, which is restricted to [a-zA-Z0-9._-]: "/" becomes "." to preserve its hierarchy, and any
+// other disallowed character (spaces, #, etc, as can appear in an OSC-address-derived topic) becomes "_".
+func kafkaTopic(topic string) string {
+	topic = strings.ReplaceAll(topic, "/", ".")
+	return kafkaTopicDisallowed.ReplaceAllString(topic, "_")
+}
+
+// Publish: Publish payload to a Kafka topic, always on partition 0. Kafka has no retained-message
+// concept, so opts.Retain is ignored.
+func (t *KafkaTransport) Publish(topic string, payload []byte, opts PubOpts) error {
+	_, _, err := t.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:     kafkaTopic(topic),
+		Value:     sarama.ByteEncoder(payload),
+		Partition: 0,
+	})
+	return err
+}
+
+// Subscribe: Consume a Kafka topic's partition 0 from the newest offset, calling handler for every message.
+func (t *KafkaTransport) Subscribe(topic string, handler MsgHandler) error {
+	pc, err := t.consumer.ConsumePartition(kafkaTopic(topic), 0, sarama.OffsetNewest)
+	if err != nil {
+		return err
+	}
+	t.consumers = append(t.consumers, pc)
+
+	go func() {
+		for msg := range pc.Messages() {
+			// Report back the relay's original (slash-delimited) topic, not the sanitized Kafka name.
+			handler(topic, msg.Value)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTransportConfig: Connection settings for a Redis Pub/Sub transport.
+type RedisTransportConfig struct {
+	// Addr: Address of the Redis server, e.g. localhost:6379.
+	Addr string `yaml:"addr" json:"addr"`
+	// Password: Password used for Redis authentication.
+	Password string `yaml:"password" json:"password"`
+	// Db: Redis database index to select.
+	Db int `yaml:"db" json:"db"`
+}
+
+// RedisTransport: Transport backed by Redis Pub/Sub.
+type RedisTransport struct {
+	cfg     *RedisTransportConfig
+	client  *redis.Client
+	pubSubs []*redis.PubSub
+}
+
+// NewRedisTransport: Creates a RedisTransport from its configuration.
+func NewRedisTransport(cfg *RedisTransportConfig) *RedisTransport {
+	return &RedisTransport{cfg: cfg}
+}
+
+// Connect: Establish the Redis connection.
+func (t *RedisTransport) Connect() error {
+	t.client = redis.NewClient(&redis.Options{
+		Addr:     t.cfg.Addr,
+		Password: t.cfg.Password,
+		DB:       t.cfg.Db,
+	})
+	return t.client.Ping(context.Background()).Err()
+}
+
+// Disconnect: Close every subscription and the Redis connection.
+func (t *RedisTransport) Disconnect() {
+	for _, pubSub := range t.pubSubs {
+		pubSub.Close()
+	}
+	if t.client != nil {
+		t.client.Close()
+	}
+}
+
+// Publish: Publish payload to a Redis channel. Redis has no retained-message concept, so opts.Retain is ignored.
+func (t *RedisTransport) Publish(topic string, payload []byte, opts PubOpts) error {
+	return t.client.Publish(context.Background(), topic, payload).Err()
+}
+
+// Subscribe: Subscribe to a Redis channel, calling handler for every message received.
+func (t *RedisTransport) Subscribe(topic string, handler MsgHandler) error {
+	pubSub := t.client.Subscribe(context.Background(), topic)
+	t.pubSubs = append(t.pubSubs, pubSub)
+
+	go func() {
+		for msg := range pubSub.Channel() {
+			handler(msg.Channel, []byte(msg.Payload))
+		}
+	}()
+	return nil
+}